@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonprovider
+
+import (
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// ActionSchema is the JSON representation of a providers.ActionSchema.
+type ActionSchema struct {
+	ConfigSchema *Block `json:"config_schema,omitempty"`
+
+	Unlinked  *UnlinkedAction  `json:"unlinked,omitempty"`
+	Linked    *LinkedAction    `json:"linked,omitempty"`
+	Lifecycle *LifecycleAction `json:"lifecycle,omitempty"`
+
+	// SchemaFingerprint is a deterministic digest of ConfigSchema and the
+	// action's linkage, excluding human-readable fields.
+	SchemaFingerprint string `json:"schema_fingerprint,omitempty"`
+}
+
+// UnlinkedAction is the JSON representation of providers.UnlinkedAction.
+type UnlinkedAction struct{}
+
+// LinkedAction is the JSON representation of providers.LinkedAction.
+type LinkedAction struct {
+	LinkedResources []LinkedResourceSchema `json:"linked_resources,omitempty"`
+}
+
+// LifecycleAction is the JSON representation of providers.LifecycleAction.
+type LifecycleAction struct {
+	LinkedResource LinkedResourceSchema `json:"linked_resource"`
+	ExecutionOrder string               `json:"execution_order,omitempty"`
+}
+
+// LinkedResourceSchema is the JSON representation of
+// providers.LinkedResourceSchema.
+type LinkedResourceSchema struct {
+	TypeName string `json:"type_name"`
+}
+
+func marshalActionSchema(a providers.ActionSchema) *ActionSchema {
+	ret := &ActionSchema{}
+
+	if a.ConfigSchema != nil {
+		ret.ConfigSchema = marshalBlock(a.ConfigSchema)
+	}
+
+	switch {
+	case a.Unlinked != nil:
+		ret.Unlinked = &UnlinkedAction{}
+	case a.Linked != nil:
+		ret.Linked = &LinkedAction{
+			LinkedResources: marshalLinkedResources(a.Linked.LinkedResources),
+		}
+	case a.Lifecycle != nil:
+		ret.Lifecycle = &LifecycleAction{
+			LinkedResource: marshalLinkedResource(a.Lifecycle.LinkedResource),
+			ExecutionOrder: executionOrderString(a.Lifecycle.Executes),
+		}
+	}
+
+	ret.SchemaFingerprint = fingerprintActionSchema(ret)
+	return ret
+}
+
+func marshalLinkedResource(lr providers.LinkedResourceSchema) LinkedResourceSchema {
+	return LinkedResourceSchema{TypeName: lr.TypeName}
+}
+
+func marshalLinkedResources(lrs []providers.LinkedResourceSchema) []LinkedResourceSchema {
+	ret := make([]LinkedResourceSchema, len(lrs))
+	for i, lr := range lrs {
+		ret[i] = marshalLinkedResource(lr)
+	}
+	return ret
+}
+
+func executionOrderString(o providers.ExecutionOrder) string {
+	switch o {
+	case providers.ExecutionOrderBefore:
+		return "before"
+	case providers.ExecutionOrderAfter:
+		return "after"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonprovider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fingerprintSize is the number of bytes of the SHA-256 digest that we keep.
+// 16 bytes (128 bits) is more than enough to make accidental collisions
+// between unrelated schemas practically impossible, while keeping the
+// fingerprint short enough to be comfortable to read and diff.
+const fingerprintSize = 16
+
+// fingerprintBlock computes a deterministic fingerprint for a Block by
+// walking its attributes and nested block types in sorted-key order and
+// hashing a canonical byte stream of their structural properties. It
+// deliberately excludes Description, DescriptionKind, and Deprecated,
+// so that documentation-only edits don't change the result.
+func fingerprintBlock(b *Block) string {
+	if b == nil {
+		return ""
+	}
+	var sb strings.Builder
+	writeBlock(&sb, b)
+	return hashString(sb.String())
+}
+
+func fingerprintActionSchema(a *ActionSchema) string {
+	var sb strings.Builder
+	writeBlock(&sb, a.ConfigSchema)
+
+	switch {
+	case a.Unlinked != nil:
+		sb.WriteString("unlinked")
+	case a.Linked != nil:
+		sb.WriteString("linked(")
+		for _, lr := range a.Linked.LinkedResources {
+			sb.WriteString(lr.TypeName)
+			sb.WriteString(",")
+		}
+		sb.WriteString(")")
+	case a.Lifecycle != nil:
+		fmt.Fprintf(&sb, "lifecycle(%s,%s)", a.Lifecycle.LinkedResource.TypeName, a.Lifecycle.ExecutionOrder)
+	}
+
+	return hashString(sb.String())
+}
+
+func fingerprintIdentitySchema(i *IdentitySchema) string {
+	var sb strings.Builder
+	writeAttributes(&sb, i.Attributes)
+	return hashString(sb.String())
+}
+
+// fingerprintProvider combines the per-entry fingerprints already computed
+// for a Provider's schemas into a single digest representing the provider's
+// whole schema surface.
+func fingerprintProvider(p *Provider) string {
+	var sb strings.Builder
+
+	if p.Provider != nil {
+		sb.WriteString("provider:")
+		sb.WriteString(p.Provider.SchemaFingerprint)
+		sb.WriteString(";")
+	}
+
+	writeSchemaMapFingerprints(&sb, "resource", p.ResourceSchemas)
+	writeSchemaMapFingerprints(&sb, "data_source", p.DataSourceSchemas)
+	writeSchemaMapFingerprints(&sb, "ephemeral_resource", p.EphemeralResourceSchemas)
+	writeSchemaMapFingerprints(&sb, "list_resource", p.ListResourceSchemas)
+
+	for _, name := range sortedIdentitySchemaKeys(p.ResourceIdentitySchemas) {
+		fmt.Fprintf(&sb, "resource_identity:%s=%s;", name, p.ResourceIdentitySchemas[name].SchemaFingerprint)
+	}
+	for _, name := range sortedActionSchemaKeys(p.ActionSchemas) {
+		fmt.Fprintf(&sb, "action:%s=%s;", name, p.ActionSchemas[name].SchemaFingerprint)
+	}
+
+	return hashString(sb.String())
+}
+
+func writeSchemaMapFingerprints(sb *strings.Builder, label string, schemas map[string]*Schema) {
+	for _, name := range sortedSchemaKeys(schemas) {
+		fmt.Fprintf(sb, "%s:%s=%s;", label, name, schemas[name].SchemaFingerprint)
+	}
+}
+
+func writeBlock(sb *strings.Builder, b *Block) {
+	sb.WriteString("block{")
+	if b != nil {
+		writeAttributes(sb, b.Attributes)
+		for _, name := range sortedBlockTypeKeys(b.BlockTypes) {
+			bt := b.BlockTypes[name]
+			fmt.Fprintf(sb, "blocktype:%s:%s:min=%d:max=%d:", name, bt.NestingMode, bt.MinItems, bt.MaxItems)
+			writeBlock(sb, bt.Block)
+			sb.WriteString(";")
+		}
+	}
+	sb.WriteString("}")
+}
+
+func writeAttributes(sb *strings.Builder, attrs map[string]*Attribute) {
+	for _, name := range sortedAttributeKeys(attrs) {
+		sb.WriteString("attr:")
+		sb.WriteString(name)
+		sb.WriteString(":")
+		writeAttribute(sb, attrs[name])
+		sb.WriteString(";")
+	}
+}
+
+func writeAttribute(sb *strings.Builder, a *Attribute) {
+	if a == nil {
+		return
+	}
+
+	switch {
+	case a.AttributeType != nil:
+		sb.Write(a.AttributeType)
+	case a.AttributeNestedType != nil:
+		nt := a.AttributeNestedType
+		fmt.Fprintf(sb, "nested(%s,min=%d,max=%d){", nt.NestingMode, nt.MinItems, nt.MaxItems)
+		writeAttributes(sb, nt.Attributes)
+		sb.WriteString("}")
+	}
+
+	fmt.Fprintf(sb, ":req=%t,opt=%t,comp=%t,sens=%t,wo=%t", a.Required, a.Optional, a.Computed, a.Sensitive, a.WriteOnly)
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:fingerprintSize])
+}
+
+func sortedAttributeKeys(m map[string]*Attribute) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedBlockTypeKeys(m map[string]*BlockType) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedSchemaKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIdentitySchemaKeys(m map[string]*IdentitySchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedActionSchemaKeys(m map[string]*ActionSchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
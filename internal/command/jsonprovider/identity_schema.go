@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonprovider
+
+import (
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// IdentitySchema is the JSON representation of a resource's identity
+// schema.
+type IdentitySchema struct {
+	Version    int64                 `json:"version"`
+	Attributes map[string]*Attribute `json:"attributes,omitempty"`
+
+	// SchemaFingerprint is a deterministic digest of Attributes, excluding
+	// human-readable fields.
+	SchemaFingerprint string `json:"schema_fingerprint,omitempty"`
+}
+
+func marshalIdentitySchemas(resourceTypes map[string]providers.Schema) map[string]*IdentitySchema {
+	ret := map[string]*IdentitySchema{}
+
+	for name, schema := range resourceTypes {
+		if schema.Identity == nil {
+			continue
+		}
+
+		identity := &IdentitySchema{
+			Version:    schema.IdentityVersion,
+			Attributes: map[string]*Attribute{},
+		}
+
+		for attrName, attr := range schema.Identity.Attributes {
+			identity.Attributes[attrName] = marshalAttribute(attr)
+		}
+
+		identity.SchemaFingerprint = fingerprintIdentitySchema(identity)
+		ret[name] = identity
+	}
+
+	return ret
+}
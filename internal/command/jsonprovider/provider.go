@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonprovider
+
+import (
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// Provider is the JSON representation of the schemas for a single provider,
+// as emitted by `terraform providers schema -json`.
+type Provider struct {
+	Provider                 *Schema                    `json:"provider,omitempty"`
+	ResourceSchemas          map[string]*Schema         `json:"resource_schemas,omitempty"`
+	DataSourceSchemas        map[string]*Schema         `json:"data_source_schemas,omitempty"`
+	EphemeralResourceSchemas map[string]*Schema         `json:"ephemeral_resource_schemas,omitempty"`
+	ListResourceSchemas      map[string]*Schema         `json:"list_resource_schemas,omitempty"`
+	ResourceIdentitySchemas  map[string]*IdentitySchema `json:"resource_identity_schemas,omitempty"`
+	ActionSchemas            map[string]*ActionSchema   `json:"action_schemas,omitempty"`
+
+	// SchemaFingerprint is a deterministic digest of this provider's whole
+	// schema surface, derived from the fingerprints of the entries above. It lets
+	// downstream tooling detect that a provider's schema changed without
+	// hashing (and being invalidated by reordering or doc edits in) the
+	// entire JSON document.
+	SchemaFingerprint string `json:"schema_fingerprint,omitempty"`
+}
+
+// marshalProvider converts s into its JSON representation, gated by
+// includeExperimental for schema surfaces (list resources, actions) that
+// haven't stabilized yet.
+func marshalProvider(s providers.ProviderSchema, includeExperimental bool) *Provider {
+	ret := &Provider{
+		Provider:                 marshalSchema(s.Provider),
+		ResourceSchemas:          marshalSchemas(s.ResourceTypes),
+		DataSourceSchemas:        marshalSchemas(s.DataSources),
+		EphemeralResourceSchemas: marshalSchemas(s.EphemeralResourceTypes),
+		ResourceIdentitySchemas:  marshalIdentitySchemas(s.ResourceTypes),
+		ActionSchemas:            map[string]*ActionSchema{},
+	}
+
+	if includeExperimental {
+		if len(s.ListResourceTypes) > 0 {
+			ret.ListResourceSchemas = marshalSchemas(s.ListResourceTypes)
+		}
+		for name, action := range s.Actions {
+			ret.ActionSchemas[name] = marshalActionSchema(action)
+		}
+	}
+
+	ret.SchemaFingerprint = fingerprintProvider(ret)
+	return ret
+}
+
+func marshalSchemas(in map[string]providers.Schema) map[string]*Schema {
+	out := make(map[string]*Schema, len(in))
+	for name, schema := range in {
+		out[name] = marshalSchema(schema)
+	}
+	return out
+}
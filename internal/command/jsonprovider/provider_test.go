@@ -16,7 +16,17 @@ import (
 	"github.com/hashicorp/terraform/internal/providers"
 )
 
-var cmpOpts = cmpopts.IgnoreUnexported(Provider{})
+// cmpOpts ignores the fields populated by schema fingerprinting: the
+// fingerprints are exercised directly by TestSchemaFingerprint below, and
+// asserting them here would mean updating every literal in this table each
+// time an unrelated field is added to Block or Attribute.
+var cmpOpts = cmp.Options{
+	cmpopts.IgnoreUnexported(Provider{}),
+	cmpopts.IgnoreFields(Provider{}, "SchemaFingerprint"),
+	cmpopts.IgnoreFields(Schema{}, "SchemaFingerprint"),
+	cmpopts.IgnoreFields(ActionSchema{}, "SchemaFingerprint"),
+	cmpopts.IgnoreFields(IdentitySchema{}, "SchemaFingerprint"),
+}
 
 func TestMarshalProvider(t *testing.T) {
 	tests := []struct {
@@ -491,3 +501,62 @@ func testProvider() providers.ProviderSchema {
 		},
 	}
 }
+
+func TestSchemaFingerprint(t *testing.T) {
+	resourceBlock := func() *configschema.Block {
+		return &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{
+				"id":  {Type: cty.String, Optional: true, Computed: true},
+				"ami": {Type: cty.String, Optional: true},
+			},
+		}
+	}
+
+	t.Run("map key order does not affect the fingerprint", func(t *testing.T) {
+		a := marshalSchema(providers.Schema{Body: resourceBlock()})
+
+		// Build the same block again, but with its attributes constructed
+		// in the opposite order, to guard against a fingerprint that
+		// accidentally depends on Go's randomized map iteration order.
+		block := resourceBlock()
+		names := make([]string, 0, len(block.Attributes))
+		for name := range block.Attributes {
+			names = append(names, name)
+		}
+		reordered := &configschema.Block{Attributes: map[string]*configschema.Attribute{}}
+		for i := len(names) - 1; i >= 0; i-- {
+			reordered.Attributes[names[i]] = block.Attributes[names[i]]
+		}
+		b := marshalSchema(providers.Schema{Body: reordered})
+
+		if a.SchemaFingerprint != b.SchemaFingerprint {
+			t.Fatalf("expected identical fingerprints, got %q and %q", a.SchemaFingerprint, b.SchemaFingerprint)
+		}
+	})
+
+	t.Run("a type change flips the fingerprint", func(t *testing.T) {
+		a := marshalSchema(providers.Schema{Body: resourceBlock()})
+
+		block := resourceBlock()
+		block.Attributes["ami"] = &configschema.Attribute{Type: cty.Number, Optional: true}
+		b := marshalSchema(providers.Schema{Body: block})
+
+		if a.SchemaFingerprint == b.SchemaFingerprint {
+			t.Fatalf("expected fingerprints to differ after a type change, both were %q", a.SchemaFingerprint)
+		}
+	})
+
+	t.Run("a description change does not affect the fingerprint", func(t *testing.T) {
+		a := marshalSchema(providers.Schema{Body: resourceBlock()})
+
+		block := resourceBlock()
+		block.Description = "now documented"
+		block.Attributes["ami"].Description = "the AMI to use"
+		block.Attributes["ami"].DescriptionKind = configschema.StringMarkdown
+		b := marshalSchema(providers.Schema{Body: block})
+
+		if a.SchemaFingerprint != b.SchemaFingerprint {
+			t.Fatalf("expected fingerprints to match after a description-only change, got %q and %q", a.SchemaFingerprint, b.SchemaFingerprint)
+		}
+	})
+}
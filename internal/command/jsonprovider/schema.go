@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package jsonprovider
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// Schema is the JSON representation of a provider, resource, data source, or
+// ephemeral resource schema.
+type Schema struct {
+	Version int64  `json:"version"`
+	Block   *Block `json:"block,omitempty"`
+
+	// SchemaFingerprint is a deterministic digest of Block, excluding
+	// human-readable fields such as descriptions, so documentation-only
+	// edits don't change it.
+	SchemaFingerprint string `json:"schema_fingerprint,omitempty"`
+}
+
+// Block is the JSON representation of a configschema.Block.
+type Block struct {
+	Attributes map[string]*Attribute `json:"attributes,omitempty"`
+	BlockTypes map[string]*BlockType `json:"block_types,omitempty"`
+
+	Description     string `json:"description,omitempty"`
+	DescriptionKind string `json:"description_kind,omitempty"`
+	Deprecated      bool   `json:"deprecated,omitempty"`
+}
+
+// Attribute is the JSON representation of a configschema.Attribute.
+type Attribute struct {
+	AttributeType       json.RawMessage `json:"type,omitempty"`
+	AttributeNestedType *NestedType     `json:"nested_type,omitempty"`
+
+	Description     string `json:"description,omitempty"`
+	DescriptionKind string `json:"description_kind,omitempty"`
+	Required        bool   `json:"required,omitempty"`
+	Optional        bool   `json:"optional,omitempty"`
+	Computed        bool   `json:"computed,omitempty"`
+	Sensitive       bool   `json:"sensitive,omitempty"`
+	Deprecated      bool   `json:"deprecated,omitempty"`
+	WriteOnly       bool   `json:"write_only,omitempty"`
+}
+
+// NestedType is the JSON representation of a configschema.Object nested
+// attribute type.
+type NestedType struct {
+	Attributes  map[string]*Attribute `json:"attributes,omitempty"`
+	NestingMode string                `json:"nesting_mode,omitempty"`
+	MinItems    int                   `json:"min_items,omitempty"`
+	MaxItems    int                   `json:"max_items,omitempty"`
+}
+
+// BlockType is the JSON representation of a configschema.NestedBlock.
+type BlockType struct {
+	Block       *Block `json:"block,omitempty"`
+	NestingMode string `json:"nesting_mode,omitempty"`
+	MinItems    int    `json:"min_items,omitempty"`
+	MaxItems    int    `json:"max_items,omitempty"`
+}
+
+func marshalSchema(s providers.Schema) *Schema {
+	ret := &Schema{
+		Version: s.Version,
+	}
+
+	if s.Body != nil {
+		ret.Block = marshalBlock(s.Body)
+	}
+
+	ret.SchemaFingerprint = fingerprintBlock(ret.Block)
+	return ret
+}
+
+func marshalBlock(b *configschema.Block) *Block {
+	ret := &Block{
+		Attributes:      map[string]*Attribute{},
+		Description:     b.Description,
+		DescriptionKind: marshalStringKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+
+	for name, attr := range b.Attributes {
+		ret.Attributes[name] = marshalAttribute(attr)
+	}
+
+	for name, block := range b.BlockTypes {
+		if ret.BlockTypes == nil {
+			ret.BlockTypes = map[string]*BlockType{}
+		}
+		ret.BlockTypes[name] = marshalBlockType(block)
+	}
+
+	return ret
+}
+
+func marshalAttribute(a *configschema.Attribute) *Attribute {
+	ret := &Attribute{
+		Description:     a.Description,
+		DescriptionKind: marshalStringKind(a.DescriptionKind),
+		Required:        a.Required,
+		Optional:        a.Optional,
+		Computed:        a.Computed,
+		Sensitive:       a.Sensitive,
+		Deprecated:      a.Deprecated,
+		WriteOnly:       a.WriteOnly,
+	}
+
+	if a.NestedType != nil {
+		ret.AttributeNestedType = marshalNestedType(a.NestedType)
+		return ret
+	}
+
+	ty, err := json.Marshal(a.Type)
+	if err != nil {
+		panic(err)
+	}
+	ret.AttributeType = ty
+
+	return ret
+}
+
+func marshalNestedType(o *configschema.Object) *NestedType {
+	ret := &NestedType{
+		Attributes:  map[string]*Attribute{},
+		NestingMode: nestingModeString(o.Nesting),
+	}
+
+	for name, attr := range o.Attributes {
+		ret.Attributes[name] = marshalAttribute(attr)
+	}
+
+	return ret
+}
+
+func marshalBlockType(b *configschema.NestedBlock) *BlockType {
+	return &BlockType{
+		Block:       marshalBlock(&b.Block),
+		NestingMode: nestingModeString(b.Nesting),
+		MinItems:    b.MinItems,
+		MaxItems:    b.MaxItems,
+	}
+}
+
+func nestingModeString(mode configschema.NestingMode) string {
+	switch mode {
+	case configschema.NestingSingle:
+		return "single"
+	case configschema.NestingGroup:
+		return "group"
+	case configschema.NestingList:
+		return "list"
+	case configschema.NestingSet:
+		return "set"
+	case configschema.NestingMap:
+		return "map"
+	default:
+		return ""
+	}
+}
+
+func marshalStringKind(k configschema.StringKind) string {
+	switch k {
+	case configschema.StringMarkdown:
+		return "markdown"
+	default:
+		return "plain"
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package testing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// storedResource is the on-disk representation of a single ResourceStore
+// entry. cty.Value can't be unmarshalled without knowing its cty.Type ahead
+// of time, so we carry the type alongside the value.
+type storedResource struct {
+	Type  json.RawMessage `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SaveJSON serializes the store's current resources (Data) to path, so that a
+// fixture built up during one test run (or checked into the repository by
+// hand) can be loaded again later with LoadResourceStoreJSON.
+func (store *ResourceStore) SaveJSON(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return store.WriteJSON(file)
+}
+
+// WriteJSON serializes the store's current resources (Data) to w. FailCounts
+// are not written.
+func (store *ResourceStore) WriteJSON(w io.Writer) error {
+	defer store.beginRead()()
+
+	resources := make(map[string]storedResource, len(store.Data))
+	for key, value := range store.Data {
+		ty := value.Type()
+
+		tyJSON, err := ty.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		valueJSON, err := ctyjson.Marshal(value, ty)
+		if err != nil {
+			return err
+		}
+
+		resources[key] = storedResource{
+			Type:  tyJSON,
+			Value: valueJSON,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(resources)
+}
+
+// LoadResourceStoreJSON reads a ResourceStore previously written by
+// (*ResourceStore).SaveJSON, so a terraform test suite can seed the mock
+// provider with a large fixture of "pre-existing remote objects" from a
+// checked-in JSON file.
+func LoadResourceStoreJSON(path string) (*ResourceStore, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadResourceStoreJSON(file)
+}
+
+// ReadResourceStoreJSON reads a ResourceStore previously written by
+// (*ResourceStore).WriteJSON from r.
+func ReadResourceStoreJSON(r io.Reader) (*ResourceStore, error) {
+	var resources map[string]storedResource
+	if err := json.NewDecoder(r).Decode(&resources); err != nil {
+		return nil, err
+	}
+
+	store := &ResourceStore{
+		Data:       make(map[string]cty.Value, len(resources)),
+		FailCounts: make(map[string]int),
+	}
+
+	for key, resource := range resources {
+		var ty cty.Type
+		if err := ty.UnmarshalJSON(resource.Type); err != nil {
+			return nil, err
+		}
+
+		value, err := ctyjson.Unmarshal(resource.Value, ty)
+		if err != nil {
+			return nil, err
+		}
+
+		store.Data[key] = value
+	}
+
+	return store, nil
+}
+
+// NewProviderFromFile is a convenience wrapper around LoadResourceStoreJSON
+// and NewProvider, so a test can seed a TestProvider from a checked-in
+// fixture in one line.
+func NewProviderFromFile(path string) (*TestProvider, error) {
+	store, err := LoadResourceStoreJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewProvider(store), nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package testing
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestResourceStore_WriteReadJSONRoundTrip(t *testing.T) {
+	store := &ResourceStore{
+		Data: map[string]cty.Value{
+			"test_resource/abc": cty.ObjectVal(map[string]cty.Value{
+				"id":    cty.StringVal("abc"),
+				"value": cty.StringVal("hello"),
+			}),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := store.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	got, err := ReadResourceStoreJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadResourceStoreJSON: %v", err)
+	}
+
+	want := store.Data["test_resource/abc"]
+	gotResource, ok := got.Data["test_resource/abc"]
+	if !ok {
+		t.Fatalf("round-tripped store is missing key %q", "test_resource/abc")
+	}
+	if !want.RawEquals(gotResource) {
+		t.Fatalf("round-tripped resource does not match original:\n got:  %#v\nwant: %#v", gotResource, want)
+	}
+}
+
+func TestNewProviderFromFile(t *testing.T) {
+	store := &ResourceStore{
+		Data: map[string]cty.Value{
+			"test_resource/abc": cty.ObjectVal(map[string]cty.Value{
+				"id":    cty.StringVal("abc"),
+				"value": cty.StringVal("hello"),
+			}),
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := store.SaveJSON(path); err != nil {
+		t.Fatalf("SaveJSON: %v", err)
+	}
+
+	provider, err := NewProviderFromFile(path)
+	if err != nil {
+		t.Fatalf("NewProviderFromFile: %v", err)
+	}
+
+	got := provider.Store.Get("test_resource/abc")
+	if got == cty.NilVal {
+		t.Fatalf("loaded provider's store is missing the fixture resource")
+	}
+	if got.GetAttr("value").AsString() != "hello" {
+		t.Fatalf("expected loaded resource's value to be %q, got %q", "hello", got.GetAttr("value").AsString())
+	}
+}
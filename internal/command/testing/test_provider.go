@@ -33,14 +33,19 @@ var (
 			"test_resource": {
 				Body: &configschema.Block{
 					Attributes: map[string]*configschema.Attribute{
-						"id":                   {Type: cty.String, Optional: true, Computed: true},
-						"value":                {Type: cty.String, Optional: true},
-						"interrupt_count":      {Type: cty.Number, Optional: true},
-						"destroy_fail":         {Type: cty.Bool, Optional: true, Computed: true},
-						"create_wait_seconds":  {Type: cty.Number, Optional: true},
-						"destroy_wait_seconds": {Type: cty.Number, Optional: true},
-						"write_only":           {Type: cty.String, Optional: true, WriteOnly: true},
-						"defer":                {Type: cty.Bool, Optional: true},
+						"id":                       {Type: cty.String, Optional: true, Computed: true},
+						"value":                    {Type: cty.String, Optional: true},
+						"interrupt_count":          {Type: cty.Number, Optional: true},
+						"destroy_fail":             {Type: cty.Bool, Optional: true, Computed: true},
+						"create_wait_seconds":      {Type: cty.Number, Optional: true},
+						"destroy_wait_seconds":     {Type: cty.Number, Optional: true},
+						"write_only":               {Type: cty.String, Optional: true, WriteOnly: true},
+						"defer":                    {Type: cty.Bool, Optional: true},
+						"create_fail_count":        {Type: cty.Number, Optional: true},
+						"update_fail_count":        {Type: cty.Number, Optional: true},
+						"read_fail_count":          {Type: cty.Number, Optional: true},
+						"fail_diagnostic_severity": {Type: cty.String, Optional: true},
+						"fail_error_message":       {Type: cty.String, Optional: true},
 					},
 				},
 			},
@@ -92,6 +97,39 @@ var (
 				ReturnType: cty.Bool,
 			},
 		},
+		Actions: map[string]providers.ActionSchema{
+			"test_unlinked_action": {
+				ConfigSchema: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"value": {Type: cty.String, Optional: true},
+					},
+				},
+				Unlinked: &providers.UnlinkedAction{},
+			},
+			"test_linked_action": {
+				ConfigSchema: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"value": {Type: cty.String, Optional: true},
+					},
+				},
+				Linked: &providers.LinkedAction{
+					LinkedResources: []providers.LinkedResourceSchema{
+						{TypeName: "test_resource"},
+					},
+				},
+			},
+			"test_lifecycle_action": {
+				ConfigSchema: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"value": {Type: cty.String, Optional: true},
+					},
+				},
+				Lifecycle: &providers.LifecycleAction{
+					LinkedResource: providers.LinkedResourceSchema{TypeName: "test_resource"},
+					Executes:       providers.ExecutionOrderBefore,
+				},
+			},
+		},
 	}
 )
 
@@ -105,6 +143,12 @@ type TestProvider struct {
 	Interrupt chan<- struct{}
 
 	Store *ResourceStore
+
+	// IDGenerator produces the "id" assigned to a resource on create. It
+	// defaults to uuid.GenerateUUID, but can be overridden (see
+	// NewProviderWithSeed) to make plan/apply output reproducible across
+	// test runs.
+	IDGenerator func() (string, error)
 }
 
 // NewProvider creates a new TestProvider for use in tests.
@@ -117,13 +161,15 @@ type TestProvider struct {
 func NewProvider(store *ResourceStore) *TestProvider {
 	if store == nil {
 		store = &ResourceStore{
-			Data: make(map[string]cty.Value),
+			Data:       make(map[string]cty.Value),
+			FailCounts: make(map[string]int),
 		}
 	}
 
 	provider := &TestProvider{
-		Provider: new(testing.MockProvider),
-		Store:    store,
+		Provider:    new(testing.MockProvider),
+		Store:       store,
+		IDGenerator: uuid.GenerateUUID,
 	}
 
 	provider.Provider.GetProviderSchemaResponse = ProviderSchema
@@ -135,6 +181,28 @@ func NewProvider(store *ResourceStore) *TestProvider {
 	provider.Provider.CallFunctionFn = provider.CallFunction
 	provider.Provider.OpenEphemeralResourceFn = provider.OpenEphemeralResource
 	provider.Provider.CloseEphemeralResourceFn = provider.CloseEphemeralResource
+	provider.Provider.PlanActionFn = provider.PlanAction
+	provider.Provider.InvokeActionFn = provider.InvokeAction
+
+	return provider
+}
+
+// NewProviderWithSeed creates a new TestProvider whose generated resource
+// ids are a deterministic, monotonically increasing sequence derived from
+// seed (e.g. "test-resource-1", "test-resource-2", ...) instead of random
+// UUIDs. This makes plan/apply output reproducible across test runs, which
+// is useful for golden-file assertions.
+func NewProviderWithSeed(store *ResourceStore, seed int64) *TestProvider {
+	provider := NewProvider(store)
+
+	var mu sync.Mutex
+	next := seed
+	provider.IDGenerator = func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		next++
+		return fmt.Sprintf("test-resource-%d", next), nil
+	}
 
 	return provider
 }
@@ -221,6 +289,37 @@ func (provider *TestProvider) string(prefix string) string {
 	return strings.Join(keys, ", ")
 }
 
+// actionPrefix returns the key prefix under which invocations of the named
+// action are recorded in the ResourceStore.
+func (provider *TestProvider) actionPrefix(name string) string {
+	return path.Join("actions", name) + "/"
+}
+
+// ActionCount returns the number of times the named action has been
+// invoked.
+func (provider *TestProvider) ActionCount(name string) int {
+	return provider.count(provider.actionPrefix(name))
+}
+
+// ActionString returns the keys recording invocations of the named action,
+// for use in assertions analogous to ResourceString/DataSourceString.
+func (provider *TestProvider) ActionString(name string) string {
+	return provider.string(provider.actionPrefix(name))
+}
+
+func (provider *TestProvider) PlanAction(request providers.PlanActionRequest) providers.PlanActionResponse {
+	return providers.PlanActionResponse{}
+}
+
+// InvokeAction records the invocation of request.ActionType into the
+// ResourceStore, under the "actions/" prefix, keyed by call count, so that
+// tests can assert both how many times an action was invoked and with what
+// arguments.
+func (provider *TestProvider) InvokeAction(request providers.InvokeActionRequest) providers.InvokeActionResponse {
+	provider.Store.PutNext(provider.actionPrefix(request.ActionType), request.Config)
+	return providers.InvokeActionResponse{}
+}
+
 func (provider *TestProvider) ConfigureProvider(request providers.ConfigureProviderRequest) providers.ConfigureProviderResponse {
 	provider.resource = request.Config.GetAttr("resource_prefix")
 	provider.data = request.Config.GetAttr("data_prefix")
@@ -270,10 +369,29 @@ func (provider *TestProvider) PlanResourceChange(request providers.PlanResourceC
 		}
 	}
 
-	return providers.PlanResourceChangeResponse{
+	response := providers.PlanResourceChangeResponse{
 		PlannedState: resource,
 		Deferred:     deferred,
 	}
+
+	if request.PriorState.IsNull() {
+		// This is a create. Mint (or carry forward, if we're re-planning
+		// the same change) a private correlation id so that
+		// create_fail_count can track retries of this exact resource
+		// instance, even when two sibling instances share an identical
+		// "value" (or omit it entirely).
+		if len(request.PriorPrivate) > 0 {
+			response.PlannedPrivate = request.PriorPrivate
+		} else {
+			key, err := uuid.GenerateUUID()
+			if err != nil {
+				panic(fmt.Errorf("failed to generate private key: %v", err))
+			}
+			response.PlannedPrivate = []byte(key)
+		}
+	}
+
+	return response
 }
 
 func (provider *TestProvider) ApplyResourceChange(request providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
@@ -301,10 +419,26 @@ func (provider *TestProvider) ApplyResourceChange(request providers.ApplyResourc
 
 	resource := request.PlannedState
 	id := resource.GetAttr("id")
+
+	var diags tfdiags.Diagnostics
 	if !id.IsKnown() {
-		val, err := uuid.GenerateUUID()
+		// This is a create operation. Before provisioning anything, give the
+		// create_fail_count knob a chance to simulate a retryable failure.
+		// The real id doesn't exist yet, so we track the attempt count
+		// against the private correlation id PlanResourceChange minted for
+		// this instance.
+		key := "create:" + provider.failKey(resource, request.PlannedPrivate)
+		failDiags, abort := provider.checkFailCount(resource, key, resource.GetAttr("create_fail_count"))
+		if abort {
+			return providers.ApplyResourceChangeResponse{
+				Diagnostics: failDiags,
+			}
+		}
+		diags = diags.Append(failDiags)
+
+		val, err := provider.IDGenerator()
 		if err != nil {
-			panic(fmt.Errorf("failed to generate uuid: %v", err))
+			panic(fmt.Errorf("failed to generate id: %v", err))
 		}
 
 		id = cty.StringVal(val)
@@ -312,6 +446,16 @@ func (provider *TestProvider) ApplyResourceChange(request providers.ApplyResourc
 		vals := resource.AsValueMap()
 		vals["id"] = id
 		resource = cty.ObjectVal(vals)
+	} else {
+		// This is an update operation.
+		key := "update:" + provider.GetResourceKey(id.AsString())
+		failDiags, abort := provider.checkFailCount(resource, key, resource.GetAttr("update_fail_count"))
+		if abort {
+			return providers.ApplyResourceChangeResponse{
+				Diagnostics: failDiags,
+			}
+		}
+		diags = diags.Append(failDiags)
 	}
 
 	if interrupts := resource.GetAttr("interrupt_count"); !interrupts.IsNull() && interrupts.IsKnown() && provider.Interrupt != nil {
@@ -339,7 +483,8 @@ func (provider *TestProvider) ApplyResourceChange(request providers.ApplyResourc
 
 	provider.Store.Put(provider.GetResourceKey(id.AsString()), resource)
 	return providers.ApplyResourceChangeResponse{
-		NewState: resource,
+		NewState:    resource,
+		Diagnostics: diags,
 	}
 }
 
@@ -347,17 +492,94 @@ func (provider *TestProvider) ReadResource(request providers.ReadResourceRequest
 	var diags tfdiags.Diagnostics
 
 	id := request.PriorState.GetAttr("id").AsString()
-	resource := provider.Store.Get(provider.GetResourceKey(id))
+	key := provider.GetResourceKey(id)
+	resource := provider.Store.Get(key)
 	if resource == cty.NilVal {
 		diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "not found", fmt.Sprintf("%s does not exist", id)))
+		return providers.ReadResourceResponse{
+			NewState:    resource,
+			Diagnostics: diags,
+		}
 	}
 
+	failDiags, abort := provider.checkFailCount(resource, "read:"+key, resource.GetAttr("read_fail_count"))
+	if abort {
+		return providers.ReadResourceResponse{
+			NewState:    resource,
+			Diagnostics: failDiags,
+		}
+	}
+	diags = diags.Append(failDiags)
+
 	return providers.ReadResourceResponse{
 		NewState:    resource,
 		Diagnostics: diags,
 	}
 }
 
+// failKey returns a stable identifier for a resource that hasn't been
+// assigned its "id" attribute yet, so that create_fail_count can be honored
+// across the retries of a single create operation. It prefers
+// plannedPrivate, the per-instance correlation id PlanResourceChange mints
+// for every create, since that's guaranteed unique even between sibling
+// instances with an identical (or entirely absent) "value".
+func (provider *TestProvider) failKey(resource cty.Value, plannedPrivate []byte) string {
+	if len(plannedPrivate) > 0 {
+		return string(plannedPrivate)
+	}
+	if value := resource.GetAttr("value"); !value.IsNull() && value.IsKnown() {
+		return provider.GetResourceKey(value.AsString())
+	}
+	return provider.GetResourceKey("")
+}
+
+// checkFailCount consults the ResourceStore's fail count for key and, if the
+// configured maxCount has not yet been exhausted, returns diagnostics
+// simulating a transient failure and increments the count. Once maxCount
+// attempts have failed, it returns no diagnostics so that callers can
+// proceed as normal.
+//
+// The returned bool reports whether the caller should abort the current
+// operation: this is only true when the injected diagnostics are at Error
+// severity. A Warning is non-fatal, so the caller is expected to complete
+// the operation as usual and simply attach the returned diagnostics.
+func (provider *TestProvider) checkFailCount(resource cty.Value, key string, maxCount cty.Value) (tfdiags.Diagnostics, bool) {
+	if maxCount.IsNull() || !maxCount.IsKnown() {
+		return nil, false
+	}
+
+	max, _ := maxCount.AsBigFloat().Int64()
+	if max <= 0 {
+		return nil, false
+	}
+
+	attempt := provider.Store.IncrementFailCount(key)
+	if int64(attempt) > max {
+		return nil, false
+	}
+
+	diags := provider.failDiagnostics(resource)
+	return diags, diags.HasErrors()
+}
+
+// failDiagnostics builds the diagnostics returned for a simulated failure,
+// honoring the fail_diagnostic_severity and fail_error_message attributes.
+func (provider *TestProvider) failDiagnostics(resource cty.Value) tfdiags.Diagnostics {
+	severity := tfdiags.Error
+	if sev := resource.GetAttr("fail_diagnostic_severity"); !sev.IsNull() && sev.IsKnown() && strings.EqualFold(sev.AsString(), "warning") {
+		severity = tfdiags.Warning
+	}
+
+	message := "simulated transient failure injected by test_resource"
+	if msg := resource.GetAttr("fail_error_message"); !msg.IsNull() && msg.IsKnown() {
+		message = msg.AsString()
+	}
+
+	var diags tfdiags.Diagnostics
+	diags = diags.Append(tfdiags.Sourceless(severity, "Injected failure", message))
+	return diags
+}
+
 func (provider *TestProvider) ReadDataSource(request providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
 	var diags tfdiags.Diagnostics
 
@@ -413,6 +635,16 @@ type ResourceStore struct {
 	mutex sync.RWMutex
 
 	Data map[string]cty.Value
+
+	// FailCounts tracks how many times a fault-injected operation
+	// (create_fail_count, update_fail_count, read_fail_count) has been
+	// attempted, keyed by operation and resource ("update:<key>",
+	// "read:<key>", "create:<correlation id>"). Update and read counts are
+	// keyed by the resource's id, so they are honored across separate
+	// plan/apply cycles that share the same store. Create counts are keyed
+	// by the private correlation id PlanResourceChange mints for each planned
+	// create, so a fresh plan of the same resource starts a new count.
+	FailCounts map[string]int
 }
 
 func (store *ResourceStore) Delete(key string) cty.Value {
@@ -439,6 +671,39 @@ func (store *ResourceStore) Put(key string, resource cty.Value) cty.Value {
 	return old
 }
 
+// PutNext atomically assigns the next sequential key under prefix (1-based,
+// counting existing entries with that prefix) and stores resource under it,
+// returning the key used. Callers that need a "call count" style key, such
+// as InvokeAction, must use this instead of combining a count lookup with a
+// separate Put: doing those as two calls would race, since another goroutine
+// could insert under the same prefix in between.
+func (store *ResourceStore) PutNext(prefix string, resource cty.Value) string {
+	defer store.beginWrite()()
+
+	count := 0
+	for key := range store.Data {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+
+	key := fmt.Sprintf("%s%d", prefix, count+1)
+	store.Data[key] = resource
+	return key
+}
+
+// IncrementFailCount records another failed attempt for key and returns the
+// new attempt count.
+func (store *ResourceStore) IncrementFailCount(key string) int {
+	defer store.beginWrite()()
+
+	if store.FailCounts == nil {
+		store.FailCounts = make(map[string]int)
+	}
+	store.FailCounts[key]++
+	return store.FailCounts[key]
+}
+
 func (store *ResourceStore) get(key string) cty.Value {
 	if resource, ok := store.Data[key]; ok {
 		return resource
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// testResource builds a cty.Value conforming to the test_resource schema,
+// with every attribute defaulted to null (or, for the attributes the
+// provider always fills in itself, a known zero value) so that individual
+// tests only need to specify the attributes they care about.
+func testResource(overrides map[string]cty.Value) cty.Value {
+	vals := map[string]cty.Value{
+		"id":                       cty.NullVal(cty.String),
+		"value":                    cty.NullVal(cty.String),
+		"interrupt_count":          cty.NullVal(cty.Number),
+		"destroy_fail":             cty.False,
+		"create_wait_seconds":      cty.NullVal(cty.Number),
+		"destroy_wait_seconds":     cty.NullVal(cty.Number),
+		"write_only":               cty.NullVal(cty.String),
+		"defer":                    cty.NullVal(cty.Bool),
+		"create_fail_count":        cty.NullVal(cty.Number),
+		"update_fail_count":        cty.NullVal(cty.Number),
+		"read_fail_count":          cty.NullVal(cty.Number),
+		"fail_diagnostic_severity": cty.NullVal(cty.String),
+		"fail_error_message":       cty.NullVal(cty.String),
+	}
+	for k, v := range overrides {
+		vals[k] = v
+	}
+	return cty.ObjectVal(vals)
+}
+
+func TestApplyResourceChange_FailCountsAreIndependent(t *testing.T) {
+	provider := NewProvider(nil)
+
+	resource := testResource(map[string]cty.Value{
+		"id":                cty.StringVal("abc"),
+		"update_fail_count": cty.NumberIntVal(2),
+		"read_fail_count":   cty.NumberIntVal(2),
+	})
+
+	// The update_fail_count budget should be exhausted after two failed
+	// attempts, independently of read_fail_count.
+	for attempt := 1; attempt <= 2; attempt++ {
+		resp := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{PlannedState: resource})
+		if !resp.Diagnostics.HasErrors() {
+			t.Fatalf("update attempt %d: expected an injected failure, got none", attempt)
+		}
+	}
+	resp := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{PlannedState: resource})
+	if resp.Diagnostics.HasErrors() {
+		t.Fatalf("update attempt 3: expected the update to succeed once update_fail_count is exhausted, got %s", resp.Diagnostics.Err())
+	}
+
+	// read_fail_count must still have its own two attempts left, unaffected
+	// by the update attempts above.
+	for attempt := 1; attempt <= 2; attempt++ {
+		resp := provider.ReadResource(providers.ReadResourceRequest{PriorState: resource})
+		if !resp.Diagnostics.HasErrors() {
+			t.Fatalf("read attempt %d: expected an injected failure, got none (update and read fail counts are not independent)", attempt)
+		}
+	}
+	readResp := provider.ReadResource(providers.ReadResourceRequest{PriorState: resource})
+	if readResp.Diagnostics.HasErrors() {
+		t.Fatalf("read attempt 3: expected the read to succeed once read_fail_count is exhausted, got %s", readResp.Diagnostics.Err())
+	}
+}
+
+func TestApplyResourceChange_WarningDoesNotAbort(t *testing.T) {
+	provider := NewProvider(nil)
+
+	resource := testResource(map[string]cty.Value{
+		"id":                       cty.StringVal("xyz"),
+		"update_fail_count":        cty.NumberIntVal(1),
+		"fail_diagnostic_severity": cty.StringVal("warning"),
+	})
+
+	resp := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{PlannedState: resource})
+	if resp.Diagnostics.HasErrors() {
+		t.Fatalf("a warning-severity injected failure should not abort the apply, got errors: %s", resp.Diagnostics.Err())
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Fatalf("expected the injected warning to still be attached to the response")
+	}
+	if resp.NewState.IsNull() || !resp.NewState.IsKnown() {
+		t.Fatalf("expected NewState to be the completed resource, got %#v", resp.NewState)
+	}
+	if got := resp.NewState.GetAttr("id"); got.AsString() != "xyz" {
+		t.Fatalf("expected NewState to carry through id %q, got %q", "xyz", got.AsString())
+	}
+}
+
+func TestFailKey_DistinctForSiblingsWithoutValue(t *testing.T) {
+	provider := NewProvider(nil)
+
+	newResource := func() cty.Value {
+		return testResource(map[string]cty.Value{
+			"id":                cty.UnknownVal(cty.String),
+			"create_fail_count": cty.NumberIntVal(1),
+		})
+	}
+
+	plan1 := provider.PlanResourceChange(providers.PlanResourceChangeRequest{
+		ProposedNewState: newResource(),
+		PriorState:       cty.NullVal(newResource().Type()),
+	})
+	plan2 := provider.PlanResourceChange(providers.PlanResourceChangeRequest{
+		ProposedNewState: newResource(),
+		PriorState:       cty.NullVal(newResource().Type()),
+	})
+
+	if len(plan1.PlannedPrivate) == 0 || len(plan2.PlannedPrivate) == 0 {
+		t.Fatalf("expected both creates to be assigned a private correlation id")
+	}
+	if bytes.Equal(plan1.PlannedPrivate, plan2.PlannedPrivate) {
+		t.Fatalf("expected sibling creates to get distinct correlation ids, got the same one for both")
+	}
+
+	// Both instances share the same create_fail_count budget of one failed
+	// attempt each. If failKey fell back to a shared key (because neither
+	// sets "value"), the second Apply would see an already-exhausted
+	// counter and succeed immediately instead of failing its own first
+	// attempt.
+	apply1 := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{
+		PlannedState:   plan1.PlannedState,
+		PlannedPrivate: plan1.PlannedPrivate,
+	})
+	if !apply1.Diagnostics.HasErrors() {
+		t.Fatalf("expected the first sibling's create to fail its first attempt")
+	}
+
+	apply2 := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{
+		PlannedState:   plan2.PlannedState,
+		PlannedPrivate: plan2.PlannedPrivate,
+	})
+	if !apply2.Diagnostics.HasErrors() {
+		t.Fatalf("expected the second sibling's create to also fail its first attempt, but its fail count collided with the first sibling's")
+	}
+}
+
+func TestNewProviderWithSeed_DeterministicIDs(t *testing.T) {
+	provider := NewProviderWithSeed(nil, 100)
+
+	for i, want := range []string{"test-resource-101", "test-resource-102", "test-resource-103"} {
+		resource := testResource(map[string]cty.Value{"id": cty.UnknownVal(cty.String)})
+		resp := provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{PlannedState: resource})
+		if resp.Diagnostics.HasErrors() {
+			t.Fatalf("create %d: unexpected error: %s", i, resp.Diagnostics.Err())
+		}
+		if got := resp.NewState.GetAttr("id").AsString(); got != want {
+			t.Fatalf("create %d: expected id %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestInvokeAction_ConcurrentInvocationsAreCounted(t *testing.T) {
+	provider := NewProvider(nil)
+
+	const n = 50
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			provider.InvokeAction(providers.InvokeActionRequest{
+				ActionType: "test_unlinked_action",
+				Config:     cty.ObjectVal(map[string]cty.Value{"value": cty.StringVal(fmt.Sprintf("v%d", i))}),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if got := provider.ActionCount("test_unlinked_action"); got != n {
+		t.Fatalf("expected %d recorded invocations, got %d (concurrent invocations overwrote each other's keys)", n, got)
+	}
+}
@@ -63,6 +63,35 @@ func (n *nodeVariableValidation) References() []*addrs.Reference {
 	return ret
 }
 
+// crossVariableReferences returns the subset of this node's references that
+// name another input variable declared in the same module as the one being
+// validated. [variableValidationTransformer] uses this to connect a
+// validation node to the node that decides the final value of the sibling
+// variable it refers to, in addition to the variable being validated.
+func (n *nodeVariableValidation) crossVariableReferences() []*addrs.Reference {
+	var ret []*addrs.Reference
+	for _, ref := range n.References() {
+		if _, ok := ref.Subject.(addrs.InputVariable); ok {
+			ret = append(ret, ref)
+		}
+	}
+	return ret
+}
+
+// moduleOutputReferences returns the subset of this node's references that
+// name an output belonging to a child module call, so that a parent-scoped
+// validation rule can depend on the node that decides that output's final
+// value.
+func (n *nodeVariableValidation) moduleOutputReferences() []*addrs.Reference {
+	var ret []*addrs.Reference
+	for _, ref := range n.References() {
+		if _, ok := ref.Subject.(addrs.ModuleCallInstanceOutput); ok {
+			ret = append(ret, ref)
+		}
+	}
+	return ret
+}
+
 // appendRefsFilterSelf is a specialized version of builtin [append] that
 // ignores any new references to the input variable represented by the
 // reciever.
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/dag"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// variableValidationTransformer connects each [nodeVariableValidation] to
+// the graph nodes it depends on: the node that decides the final value of
+// the variable being validated, the final-value nodes of any sibling
+// variables its validation rules reference, and the output nodes of any
+// child modules referenced via module.<name>.<output>.
+//
+// It also rejects, with a diagnostic citing the variables involved, any set
+// of cross-variable validation rules that would introduce a dependency
+// cycle, since that can never be resolved at evaluation time.
+type variableValidationTransformer struct{}
+
+func (t *variableValidationTransformer) Transform(g *Graph) error {
+	var diags tfdiags.Diagnostics
+
+	validationNodes := make(map[addrs.ConfigInputVariable]*nodeVariableValidation)
+	finalValueNodes := make(map[addrs.ConfigInputVariable][]dag.Vertex)
+
+	for _, v := range g.Vertices() {
+		if vn, ok := v.(*nodeVariableValidation); ok {
+			validationNodes[vn.configAddr] = vn
+			continue
+		}
+		if rn, ok := v.(GraphNodeReferenceable); ok {
+			if mp, ok := v.(GraphNodeModulePath); ok {
+				for _, addr := range rn.ReferenceableAddrs() {
+					if varAddr, ok := addr.(addrs.InputVariable); ok {
+						configAddr := addrs.ConfigInputVariable{
+							Module:   mp.ModulePath(),
+							Variable: varAddr,
+						}
+						finalValueNodes[configAddr] = append(finalValueNodes[configAddr], v)
+					}
+				}
+			}
+		}
+	}
+
+	// First, connect every validation node to the node (or nodes) that
+	// decide the final value of the variable it's validating. This is the
+	// dependency that always exists, regardless of what the rules reference.
+	for configAddr, vn := range validationNodes {
+		for _, other := range finalValueNodes[configAddr] {
+			g.Connect(dag.BasicEdge(vn, other))
+		}
+	}
+
+	// Next, wire up cross-variable and cross-module references, detecting
+	// cycles among same-module cross-variable validations as we go.
+	crossRefs := make(map[addrs.ConfigInputVariable][]addrs.ConfigInputVariable)
+
+	for configAddr, vn := range validationNodes {
+		for _, ref := range vn.crossVariableReferences() {
+			otherAddr := addrs.ConfigInputVariable{
+				Module:   configAddr.Module,
+				Variable: ref.Subject.(addrs.InputVariable),
+			}
+
+			for _, other := range finalValueNodes[otherAddr] {
+				g.Connect(dag.BasicEdge(vn, other))
+			}
+
+			crossRefs[configAddr] = append(crossRefs[configAddr], otherAddr)
+		}
+
+		for _, ref := range vn.moduleOutputReferences() {
+			outputAddr := ref.Subject.(addrs.ModuleCallInstanceOutput)
+			childModule := configAddr.Module.Child(outputAddr.Call.Call.Name)
+
+			for _, v := range g.Vertices() {
+				mp, ok := v.(GraphNodeModulePath)
+				if !ok || !mp.ModulePath().Equal(childModule) {
+					continue
+				}
+				if rn, ok := v.(GraphNodeReferenceable); ok {
+					for _, addr := range rn.ReferenceableAddrs() {
+						if outAddr, ok := addr.(addrs.ModuleOutput); ok && outAddr.Name == outputAddr.Name {
+							g.Connect(dag.BasicEdge(vn, v))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if cycleDiags := checkVariableValidationCycles(crossRefs); cycleDiags.HasErrors() {
+		diags = diags.Append(cycleDiags)
+	}
+
+	return diags.Err()
+}
+
+// checkVariableValidationCycles reports a diagnostic for each cycle found
+// among cross-variable validation references, naming the variables involved
+// so the author can see exactly which validation rules are mutually
+// dependent.
+func checkVariableValidationCycles(crossRefs map[addrs.ConfigInputVariable][]addrs.ConfigInputVariable) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[addrs.ConfigInputVariable]int)
+	reported := make(map[string]bool)
+
+	var visit func(addr addrs.ConfigInputVariable, path []addrs.ConfigInputVariable)
+	visit = func(addr addrs.ConfigInputVariable, path []addrs.ConfigInputVariable) {
+		switch state[addr] {
+		case visiting:
+			// Found a cycle: report it once, citing the two variables that
+			// close the loop.
+			cycleStart := len(path) - 1
+			for cycleStart >= 0 && path[cycleStart] != addr {
+				cycleStart--
+			}
+			cycle := append(path[cycleStart:], addr)
+			key := fmt.Sprintf("%v", cycle)
+			if !reported[key] {
+				reported[key] = true
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Cyclic variable validation",
+					fmt.Sprintf(
+						"The validation rules for %s and %s refer to each other, so there is no valid order in which to check them.",
+						cycle[0].Variable.Name, cycle[len(cycle)-2].Variable.Name,
+					),
+				))
+			}
+			return
+		case done:
+			return
+		}
+
+		state[addr] = visiting
+		for _, next := range crossRefs[addr] {
+			visit(next, append(path, addr))
+		}
+		state[addr] = done
+	}
+
+	for addr := range crossRefs {
+		if state[addr] == unvisited {
+			visit(addr, nil)
+		}
+	}
+
+	return diags
+}
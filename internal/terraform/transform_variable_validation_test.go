@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hcltest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs"
+)
+
+// fakeFinalValueNode stands in for the node that decides the final value of
+// an input variable or module output, so these tests can exercise
+// variableValidationTransformer's wiring without a full evaluation graph.
+type fakeFinalValueNode struct {
+	name string
+	path addrs.Module
+	addr addrs.Referenceable
+}
+
+func (n *fakeFinalValueNode) ModulePath() addrs.Module { return n.path }
+func (n *fakeFinalValueNode) ReferenceableAddrs() []addrs.Referenceable {
+	return []addrs.Referenceable{n.addr}
+}
+func (n *fakeFinalValueNode) Name() string { return n.name }
+
+func newTestValidationNode(module addrs.Module, variable string, conditionRef string) *nodeVariableValidation {
+	return &nodeVariableValidation{
+		configAddr: addrs.ConfigInputVariable{
+			Module:   module,
+			Variable: addrs.InputVariable{Name: variable},
+		},
+		rules: []*configs.CheckRule{
+			{
+				Condition:    hcltest.MockExprTraversalSrc(conditionRef),
+				ErrorMessage: hcltest.MockExprLiteral(cty.StringVal("invalid")),
+			},
+		},
+	}
+}
+
+func TestVariableValidationTransformer_Acyclic(t *testing.T) {
+	g := &Graph{}
+
+	aFinal := &fakeFinalValueNode{name: "var.a (final value)", path: addrs.RootModule, addr: addrs.InputVariable{Name: "a"}}
+	bFinal := &fakeFinalValueNode{name: "var.b (final value)", path: addrs.RootModule, addr: addrs.InputVariable{Name: "b"}}
+	g.Add(aFinal)
+	g.Add(bFinal)
+
+	// var.b's validation refers to var.a, so it should depend on both its
+	// own final value and var.a's, with no cycle.
+	bValidation := newTestValidationNode(addrs.RootModule, "b", "var.a")
+	g.Add(bValidation)
+
+	transformer := &variableValidationTransformer{}
+	if err := transformer.Transform(g); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	down := g.DownEdges(bValidation)
+	if !down.Include(bFinal) {
+		t.Errorf("expected %s to depend on its own final value node", bValidation.Name())
+	}
+	if !down.Include(aFinal) {
+		t.Errorf("expected %s to depend on var.a's final value node", bValidation.Name())
+	}
+}
+
+func TestVariableValidationTransformer_DetectsCycle(t *testing.T) {
+	g := &Graph{}
+
+	aFinal := &fakeFinalValueNode{name: "var.a (final value)", path: addrs.RootModule, addr: addrs.InputVariable{Name: "a"}}
+	bFinal := &fakeFinalValueNode{name: "var.b (final value)", path: addrs.RootModule, addr: addrs.InputVariable{Name: "b"}}
+	g.Add(aFinal)
+	g.Add(bFinal)
+
+	// var.a's validation refers to var.b, and var.b's validation refers
+	// back to var.a: a cycle that can never be resolved at evaluation
+	// time.
+	aValidation := newTestValidationNode(addrs.RootModule, "a", "var.b")
+	bValidation := newTestValidationNode(addrs.RootModule, "b", "var.a")
+	g.Add(aValidation)
+	g.Add(bValidation)
+
+	transformer := &variableValidationTransformer{}
+	err := transformer.Transform(g)
+	if err == nil {
+		t.Fatalf("expected an error reporting the cycle, got none")
+	}
+
+	got := err.Error()
+	if !strings.Contains(got, "Cyclic variable validation") {
+		t.Errorf("expected error to report a cyclic variable validation, got: %s", got)
+	}
+	if !strings.Contains(got, "for a and b") && !strings.Contains(got, "for b and a") {
+		t.Errorf("expected error to cite both variables involved, got: %s", got)
+	}
+}
+
+func TestVariableValidationTransformer_ModuleOutputReference(t *testing.T) {
+	g := &Graph{}
+
+	childModule := addrs.RootModule.Child("child")
+	childOutput := &fakeFinalValueNode{
+		name: "module.child.output.out (final value)",
+		path: childModule,
+		addr: addrs.ModuleOutput{Name: "out"},
+	}
+	g.Add(childOutput)
+
+	// A root-module validation rule references module.child.out.
+	parentValidation := newTestValidationNode(addrs.RootModule, "a", "module.child.out")
+	g.Add(parentValidation)
+
+	transformer := &variableValidationTransformer{}
+	if err := transformer.Transform(g); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	down := g.DownEdges(parentValidation)
+	if !down.Include(childOutput) {
+		t.Errorf("expected %s to depend on the child module's output node", parentValidation.Name())
+	}
+}
+
+// TestNodeVariableValidation_SelfReferenceFiltered is a regression test for
+// appendRefsFilterSelf: a validation rule that (directly or indirectly)
+// refers to the variable it's validating must not show up in References(),
+// since variableValidationTransformer already arranges the dependency on
+// that variable's final-value node unconditionally.
+func TestNodeVariableValidation_SelfReferenceFiltered(t *testing.T) {
+	n := newTestValidationNode(addrs.RootModule, "a", "var.a")
+
+	refs := n.References()
+	for _, ref := range refs {
+		if varAddr, ok := ref.Subject.(addrs.InputVariable); ok && varAddr.Name == "a" {
+			t.Fatalf("expected self-reference to var.a to be filtered out, got it in References()")
+		}
+	}
+}